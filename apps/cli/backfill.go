@@ -0,0 +1,87 @@
+package main
+
+import (
+  "bufio"
+  "bytes"
+  "encoding/json"
+  "flag"
+  "fmt"
+  "net/http"
+  "os"
+)
+
+type backfillProgress struct {
+  RunID     int    `json:"runId"`
+  Page      int    `json:"page"`
+  Videos    int    `json:"videos"`
+  PageToken string `json:"pageToken"`
+  Done      bool   `json:"done"`
+  Error     string `json:"error"`
+}
+
+// runBackfill drives `ytbanger backfill`, which walks a channel's uploads
+// playlist page by page. The backend streams one JSON object per line as
+// each page is processed so the CLI can show live progress instead of
+// waiting on the whole backfill to finish.
+func runBackfill(args []string) {
+  fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+  channel := fs.String("channel", "", "YouTube channel ID (UC...) to backfill")
+  pages := fs.Int("pages", 10, "max pages of uploads to walk")
+  resume := fs.String("resume", "", "resume an interrupted backfill by run ID")
+  api := fs.String("api", getenv("YTBANGER_API", "http://localhost:8080"), "backend base url")
+  fs.Parse(args)
+
+  if *channel == "" && *resume == "" {
+    fmt.Println("error: --channel or --resume is required")
+    os.Exit(1)
+  }
+
+  payload := map[string]any{
+    "channel": *channel,
+    "pages": *pages,
+    "resumeRunId": *resume,
+  }
+  body, _ := json.Marshal(payload)
+
+  client := &http.Client{Timeout: 0}
+  res, err := client.Post(*api+"/api/ingest/channel", "application/json", bytes.NewReader(body))
+  if err != nil {
+    fmt.Println("error:", err)
+    os.Exit(1)
+  }
+  defer res.Body.Close()
+
+  if res.StatusCode >= 300 {
+    fmt.Println("error: backend returned", res.StatusCode)
+    os.Exit(1)
+  }
+
+  var last backfillProgress
+  scanner := bufio.NewScanner(res.Body)
+  scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+  for scanner.Scan() {
+    line := scanner.Bytes()
+    if len(line) == 0 {
+      continue
+    }
+    var progress backfillProgress
+    if err := json.Unmarshal(line, &progress); err != nil {
+      fmt.Println("warning: couldn't parse progress line:", string(line))
+      continue
+    }
+    last = progress
+    if progress.Error != "" {
+      fmt.Println("error:", progress.Error)
+      os.Exit(1)
+    }
+    fmt.Printf("página %d | %d videos | runId=%d | nextPageToken=%s\n",
+      progress.Page, progress.Videos, progress.RunID, progress.PageToken)
+  }
+  if err := scanner.Err(); err != nil {
+    fmt.Println("warning: stream interrupted:", err)
+    fmt.Printf("reanuda con: ytbanger backfill --resume %d\n", last.RunID)
+    os.Exit(1)
+  }
+
+  fmt.Printf("Backfill de canal %s completo: run %d, %d videos\n", *channel, last.RunID, last.Videos)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+  "bytes"
+  "encoding/json"
+  "fmt"
+  "io"
+  "net/http"
+  "time"
+)
+
+type enrichSummary struct {
+  Total    int           `json:"total"`
+  Enriched int           `json:"enriched"`
+  Failed   int           `json:"failed"`
+  Videos   []enrichedVideo `json:"videos"`
+  Error    string        `json:"error"`
+}
+
+// enrichedVideo carries the fields yt-dlp can see but the YouTube Data API
+// can't: exact upload timestamp, engagement and catalog metadata, and the
+// available formats/subtitles. Error is set instead of the rest when
+// enrichment failed for that one video (e.g. yt-dlp missing or timed out).
+type enrichedVideo struct {
+  VideoID        string   `json:"videoId"`
+  UploadedAt     string   `json:"uploadedAt"`
+  LikeCount      int64    `json:"likeCount"`
+  Chapters       []string `json:"chapters"`
+  Categories     []string `json:"categories"`
+  Tags           []string `json:"tags"`
+  Formats        []string `json:"formats"`
+  SubtitleLangs  []string `json:"subtitleLangs"`
+  IsLive         bool     `json:"isLive"`
+  IsPremiere     bool     `json:"isPremiere"`
+  Error          string   `json:"error,omitempty"`
+}
+
+// enrichRun asks the backend to deep-enrich the top N videos of a run via
+// yt-dlp. The backend runs a bounded worker pool with a per-video timeout
+// and reports per-video errors instead of failing the whole request.
+func enrichRun(api string, runID, topN int) (*enrichSummary, error) {
+  payload := map[string]any{"topN": topN}
+  body, _ := json.Marshal(payload)
+
+  client := &http.Client{Timeout: 2 * time.Minute}
+  res, err := client.Post(fmt.Sprintf("%s/api/runs/%d/enrich", api, runID), "application/json", bytes.NewReader(body))
+  if err != nil {
+    return nil, err
+  }
+  defer res.Body.Close()
+
+  data, err := io.ReadAll(res.Body)
+  if err != nil {
+    return nil, err
+  }
+
+  var summary enrichSummary
+  if err := json.Unmarshal(data, &summary); err != nil {
+    return nil, fmt.Errorf("%s", string(data))
+  }
+  if res.StatusCode >= 300 {
+    return nil, fmt.Errorf("%s", summary.Error)
+  }
+  return &summary, nil
+}
@@ -8,6 +8,7 @@ import (
   "io"
   "net/http"
   "os"
+  "strings"
   "time"
 )
 
@@ -16,6 +17,18 @@ type ingestResponse struct {
   Videos  int `json:"videos"`
   Channels int `json:"channels"`
   Error   string `json:"error"`
+  Items   []videoItem `json:"items,omitempty"`
+}
+
+type videoItem struct {
+  URL          string `json:"url"`
+  Title        string `json:"title"`
+  UploaderName string `json:"uploaderName"`
+  Views        int64  `json:"views"`
+  Duration     int    `json:"duration"`
+  UploadedDate string `json:"uploadedDate"`
+  Thumbnail    string `json:"thumbnail"`
+  Instance     string `json:"instance,omitempty"`
 }
 
 type runDetails struct {
@@ -34,41 +47,56 @@ type runDetails struct {
     SubscriberCount float64 `json:"subscriber_count"`
     TotalViews float64 `json:"total_views"`
   } `json:"channels"`
+  EnrichedVideos []enrichedVideo `json:"enriched_videos,omitempty"`
 }
 
 func main() {
-  query := flag.String("query", "IA aplicada al desarrollo de software", "query to analyze")
-  max := flag.Int("max", 25, "max results 5-50")
-  api := flag.String("api", getenv("YTBANGER_API", "http://localhost:8080"), "backend base url")
-  flag.Parse()
-
-  payload := map[string]any{
-    "query": *query,
-    "maxResults": *max,
-    "regionCode": "ES",
-    "language": "es",
+  if len(os.Args) > 1 {
+    switch os.Args[1] {
+    case "backfill":
+      runBackfill(os.Args[2:])
+      return
+    case "watch":
+      runWatch(os.Args[2:])
+      return
+    case "summarize":
+      runSummarize(os.Args[2:])
+      return
+    }
   }
+  runIngest(os.Args[1:])
+}
 
-  body, _ := json.Marshal(payload)
-  res, err := http.Post(*api+"/api/ingest/youtube", "application/json", bytes.NewReader(body))
-  if err != nil {
-    fmt.Println("error:", err)
-    os.Exit(1)
-  }
-  defer res.Body.Close()
+func runIngest(args []string) {
+  fs := flag.NewFlagSet("ytbanger", flag.ExitOnError)
+  query := fs.String("query", "IA aplicada al desarrollo de software", "query to analyze")
+  max := fs.Int("max", 25, "max results 5-50")
+  api := fs.String("api", getenv("YTBANGER_API", "http://localhost:8080"), "backend base url")
+  source := fs.String("source", "youtube", "ingestion source: youtube or piped")
+  pipedInstances := fs.String("piped-instances", "pipedapi.kavin.rocks,api.piped.yt,pipedapi.moomoo.me", "comma-separated Piped instance hostnames (used when --source=piped)")
+  enrich := fs.Int("enrich", 0, "deep-enrich the top N videos of the run via yt-dlp (0 disables)")
+  fs.Parse(args)
 
-  data, _ := io.ReadAll(res.Body)
-  var ingest ingestResponse
-  if err := json.Unmarshal(data, &ingest); err != nil {
-    fmt.Println("error:", string(data))
+  var ingest *ingestResponse
+  var err error
+  switch *source {
+  case "youtube":
+    ingest, err = ingestViaYouTube(*api, *query, *max)
+  case "piped":
+    ingest, err = ingestViaPiped(*api, *query, *max, splitInstances(*pipedInstances))
+  default:
+    fmt.Println("error: unknown --source", *source)
     os.Exit(1)
   }
-  if res.StatusCode >= 300 {
-    fmt.Println("error:", ingest.Error)
+  if err != nil {
+    fmt.Println("error:", err)
     os.Exit(1)
   }
 
   fmt.Printf("Run %d creado: %d videos, %d canales\n", ingest.RunID, ingest.Videos, ingest.Channels)
+  for _, item := range ingest.Items {
+    fmt.Printf("- [%s] %s\n", item.Instance, item.Title)
+  }
 
   run, err := fetchRun(*api, ingest.RunID)
   if err != nil {
@@ -86,6 +114,50 @@ func main() {
     }
     fmt.Printf("- %s | %.0f subs | %.0f vistas\n", ch.Title, ch.SubscriberCount, ch.TotalViews)
   }
+
+  if *enrich > 0 {
+    summary, err := enrichRun(*api, ingest.RunID, *enrich)
+    if err != nil {
+      fmt.Println("warning: enrich failed:", err)
+      return
+    }
+    fmt.Printf("%d/%d enriched, %d failed\n", summary.Enriched, summary.Total, summary.Failed)
+    for _, v := range summary.Videos {
+      if v.Error != "" {
+        fmt.Printf("- %s: error: %s\n", v.VideoID, v.Error)
+      }
+    }
+  }
+}
+
+func ingestViaYouTube(api, query string, max int) (*ingestResponse, error) {
+  payload := map[string]any{
+    "query": query,
+    "maxResults": max,
+    "regionCode": "ES",
+    "language": "es",
+  }
+
+  body, _ := json.Marshal(payload)
+  res, err := http.Post(api+"/api/ingest/youtube", "application/json", bytes.NewReader(body))
+  if err != nil {
+    return nil, err
+  }
+  defer res.Body.Close()
+
+  data, err := io.ReadAll(res.Body)
+  if err != nil {
+    return nil, err
+  }
+
+  var ingest ingestResponse
+  if err := json.Unmarshal(data, &ingest); err != nil {
+    return nil, fmt.Errorf("%s", string(data))
+  }
+  if res.StatusCode >= 300 {
+    return nil, fmt.Errorf("%s", ingest.Error)
+  }
+  return &ingest, nil
 }
 
 func fetchRun(api string, runID int) (*runDetails, error) {
@@ -114,3 +186,13 @@ func getenv(key, fallback string) string {
   }
   return fallback
 }
+
+func splitInstances(raw string) []string {
+  var instances []string
+  for _, part := range strings.Split(raw, ",") {
+    if trimmed := strings.TrimSpace(part); trimmed != "" {
+      instances = append(instances, trimmed)
+    }
+  }
+  return instances
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+  "bytes"
+  "encoding/json"
+  "fmt"
+  "io"
+  "net/http"
+)
+
+// ingestViaPiped asks the backend to round-robin the given Piped instances
+// for the query instead of hitting the YouTube Data API. Instances that
+// time out or answer non-2xx are marked disabled server-side for a cool-off
+// period and skipped on subsequent requests.
+func ingestViaPiped(api, query string, max int, instances []string) (*ingestResponse, error) {
+  if len(instances) == 0 {
+    return nil, fmt.Errorf("at least one --piped-instances hostname is required")
+  }
+
+  payload := map[string]any{
+    "query": query,
+    "maxResults": max,
+    "regionCode": "ES",
+    "language": "es",
+    "instances": instances,
+  }
+
+  body, _ := json.Marshal(payload)
+  res, err := http.Post(api+"/api/ingest/piped", "application/json", bytes.NewReader(body))
+  if err != nil {
+    return nil, err
+  }
+  defer res.Body.Close()
+
+  data, err := io.ReadAll(res.Body)
+  if err != nil {
+    return nil, err
+  }
+
+  var ingest ingestResponse
+  if err := json.Unmarshal(data, &ingest); err != nil {
+    return nil, fmt.Errorf("%s", string(data))
+  }
+  if res.StatusCode >= 300 {
+    return nil, fmt.Errorf("%s", ingest.Error)
+  }
+  return &ingest, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+  "bytes"
+  "encoding/json"
+  "flag"
+  "fmt"
+  "io"
+  "net/http"
+  "os"
+  "time"
+)
+
+type summaryResponse struct {
+  Clusters []videoCluster `json:"clusters"`
+  Error    string         `json:"error"`
+}
+
+type videoCluster struct {
+  TopTopics    []string `json:"topTopics"`
+  StrongestVideo string `json:"strongestVideo"`
+  VideoCount   int      `json:"videoCount"`
+}
+
+// runSummarize drives `ytbanger summarize`, which has the backend fetch a
+// transcript per video, send it to a pluggable LLM provider for a
+// {summary, topics, sentiment} verdict, then cluster videos by shared
+// topics. The LLM endpoint/key and per-video cache live server-side
+// (YTBANGER_LLM_URL / YTBANGER_LLM_KEY); the CLI only picks the model and
+// the call concurrency.
+func runSummarize(args []string) {
+  fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+  runID := fs.Int("run", 0, "run ID to summarize")
+  model := fs.String("model", "gpt-4o-mini", "LLM model name")
+  concurrency := fs.Int("concurrency", 4, "max concurrent LLM calls")
+  api := fs.String("api", getenv("YTBANGER_API", "http://localhost:8080"), "backend base url")
+  fs.Parse(args)
+
+  if *runID == 0 {
+    fmt.Println("error: --run is required")
+    os.Exit(1)
+  }
+
+  summary, err := summarizeRun(*api, *runID, *model, *concurrency)
+  if err != nil {
+    fmt.Println("error:", err)
+    os.Exit(1)
+  }
+
+  for i, cluster := range summary.Clusters {
+    fmt.Printf("Cluster %d (%d videos) | temas: %s\n", i+1, cluster.VideoCount, joinTop3(cluster.TopTopics))
+    fmt.Printf("  destacado: %s\n", cluster.StrongestVideo)
+  }
+}
+
+func summarizeRun(api string, runID int, model string, concurrency int) (*summaryResponse, error) {
+  payload := map[string]any{
+    "model": model,
+    "concurrency": concurrency,
+  }
+  body, _ := json.Marshal(payload)
+
+  client := &http.Client{Timeout: 5 * time.Minute}
+  res, err := client.Post(fmt.Sprintf("%s/api/runs/%d/summary", api, runID), "application/json", bytes.NewReader(body))
+  if err != nil {
+    return nil, err
+  }
+  defer res.Body.Close()
+
+  data, err := io.ReadAll(res.Body)
+  if err != nil {
+    return nil, err
+  }
+
+  var summary summaryResponse
+  if err := json.Unmarshal(data, &summary); err != nil {
+    return nil, fmt.Errorf("%s", string(data))
+  }
+  if res.StatusCode >= 300 {
+    return nil, fmt.Errorf("%s", summary.Error)
+  }
+  return &summary, nil
+}
+
+func joinTop3(topics []string) string {
+  if len(topics) > 3 {
+    topics = topics[:3]
+  }
+  out := ""
+  for i, t := range topics {
+    if i > 0 {
+      out += ", "
+    }
+    out += t
+  }
+  return out
+}
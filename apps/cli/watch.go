@@ -0,0 +1,122 @@
+package main
+
+import (
+  "bytes"
+  "encoding/json"
+  "flag"
+  "fmt"
+  "io"
+  "net/http"
+  "os"
+  "time"
+)
+
+type runDiff struct {
+  New   []diffVideo `json:"new"`
+  Gone  []diffVideo `json:"gone"`
+  Moved []diffVideo `json:"moved"`
+  Error string      `json:"error"`
+}
+
+type diffVideo struct {
+  Title      string `json:"title"`
+  ViewsDelta int64  `json:"viewsDelta"`
+  LikesDelta int64  `json:"likesDelta"`
+}
+
+// runWatch drives `ytbanger watch`, which repeats an ingest on a fixed
+// interval and reports how the result set changed against the previous
+// run: videos that newly appeared, videos that dropped out, and per-video
+// view/like deltas for the ones that stuck around.
+func runWatch(args []string) {
+  fs := flag.NewFlagSet("watch", flag.ExitOnError)
+  query := fs.String("query", "IA aplicada al desarrollo de software", "query to analyze")
+  max := fs.Int("max", 25, "max results 5-50")
+  api := fs.String("api", getenv("YTBANGER_API", "http://localhost:8080"), "backend base url")
+  every := fs.Duration("every", 6*time.Hour, "interval between runs, e.g. 6h")
+  runs := fs.Int("runs", 10, "number of runs before stopping")
+  webhook := fs.String("webhook", "", "Slack/Discord-compatible webhook URL to POST each diff to")
+  fs.Parse(args)
+
+  var previousRunID int
+  for i := 0; i < *runs; i++ {
+    ingest, err := ingestViaYouTube(*api, *query, *max)
+    if err != nil {
+      fmt.Println("error:", err)
+      os.Exit(1)
+    }
+    fmt.Printf("Run %d creado: %d videos, %d canales\n", ingest.RunID, ingest.Videos, ingest.Channels)
+
+    if previousRunID != 0 {
+      diff, err := fetchDiff(*api, ingest.RunID, previousRunID)
+      if err != nil {
+        fmt.Println("warning: diff failed:", err)
+      } else {
+        printDiff(diff)
+        if *webhook != "" {
+          if err := postWebhook(*webhook, diff); err != nil {
+            fmt.Println("warning: webhook delivery failed:", err)
+          }
+        }
+      }
+    }
+
+    previousRunID = ingest.RunID
+    if i < *runs-1 {
+      time.Sleep(*every)
+    }
+  }
+}
+
+func fetchDiff(api string, newRunID, againstRunID int) (*runDiff, error) {
+  client := &http.Client{Timeout: 15 * time.Second}
+  url := fmt.Sprintf("%s/api/runs/%d/diff?against=%d", api, newRunID, againstRunID)
+  res, err := client.Get(url)
+  if err != nil {
+    return nil, err
+  }
+  defer res.Body.Close()
+
+  data, err := io.ReadAll(res.Body)
+  if err != nil {
+    return nil, err
+  }
+
+  var diff runDiff
+  if err := json.Unmarshal(data, &diff); err != nil {
+    return nil, fmt.Errorf("%s", string(data))
+  }
+  if res.StatusCode >= 300 {
+    return nil, fmt.Errorf("%s", diff.Error)
+  }
+  return &diff, nil
+}
+
+func printDiff(diff *runDiff) {
+  for _, v := range diff.New {
+    fmt.Printf("NEW  %s\n", v.Title)
+  }
+  for _, v := range diff.Gone {
+    fmt.Printf("GONE %s\n", v.Title)
+  }
+  for _, v := range diff.Moved {
+    arrow := "▲"
+    if v.ViewsDelta < 0 {
+      arrow = "▼"
+    }
+    fmt.Printf("%s %s | vistas %+d | likes %+d\n", arrow, v.Title, v.ViewsDelta, v.LikesDelta)
+  }
+}
+
+func postWebhook(url string, diff *runDiff) error {
+  body, _ := json.Marshal(diff)
+  res, err := http.Post(url, "application/json", bytes.NewReader(body))
+  if err != nil {
+    return err
+  }
+  defer res.Body.Close()
+  if res.StatusCode >= 300 {
+    return fmt.Errorf("webhook returned status %d", res.StatusCode)
+  }
+  return nil
+}